@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/subtle"
+	"log"
+	"os"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+// authMiddlewareFromEnv builds a Gin middleware from the AUTH_MODE
+// environment variable ("basic", "bearer", or unset/"none"), gating every
+// route behind HTTP Basic or bearer-token auth so wallet operations aren't
+// exposed to any local caller. It refuses to start (log.Fatal) if AUTH_MODE
+// selects a scheme whose credential env var is empty, rather than silently
+// building a middleware that treats an empty credential as a match.
+func authMiddlewareFromEnv() gin.HandlerFunc {
+	switch os.Getenv("AUTH_MODE") {
+	case "basic":
+		user, pass := os.Getenv("AUTH_USER"), os.Getenv("AUTH_PASS")
+		if user == "" || pass == "" {
+			log.Fatal("AUTH_MODE=basic requires AUTH_USER and AUTH_PASS to be set")
+		}
+		return basicAuthMiddleware(user, pass)
+	case "bearer":
+		token := os.Getenv("AUTH_TOKEN")
+		if token == "" {
+			log.Fatal("AUTH_MODE=bearer requires AUTH_TOKEN to be set")
+		}
+		return bearerAuthMiddleware(token)
+	default:
+		return func(c *gin.Context) {}
+	}
+}
+
+func basicAuthMiddleware(user, pass string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqUser, reqPass, ok := c.Request.BasicAuth()
+		if !ok || !constantTimeEqual(reqUser, user) || !constantTimeEqual(reqPass, pass) {
+			c.Header("WWW-Authenticate", `Basic realm="xmr-be"`)
+			c.AbortWithStatusJSON(401, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func bearerAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		reqToken := strings.TrimPrefix(header, "Bearer ")
+		if reqToken == header || !constantTimeEqual(reqToken, token) {
+			c.AbortWithStatusJSON(401, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func constantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
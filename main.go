@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"xmr-be/rpc"
+	"xmr-be/rpc/wallet"
 
 	gin "github.com/gin-gonic/gin"
 )
@@ -19,29 +21,47 @@ func main() {
 		host = "127.0.0.1"
 	}
 
+	walletRPC, err := rpc.NewClient(rpc.Config{
+		Address:  "127.0.0.1",
+		Port:     18081,
+		CertPath: "monero_rpc.crt",
+	})
+	if err != nil {
+		log.Fatalf("Failed to create Monero wallet RPC client: %v", err)
+	}
+	walletClient := wallet.New(walletRPC)
+
 	router := gin.Default()
 	router.SetTrustedProxies([]string{"127.0.0.1"})
+	router.Use(authMiddlewareFromEnv())
 
 	router.GET("/", func(c *gin.Context) {
-		rpc.DialMoneroServer("monero_rpc.crt", "127.0.0.1", 18081, "", "")
-		resp, err := rpc.MakeRequest(rpc.MoneroRPCRequest{
-			Jsonrpc: "2.0",
-			Method:  "get_address",
-			Params:  map[string]interface{}{},
-			ID:      0,
-		})
+		resp, err := walletClient.GetAddress(c.Request.Context(), 0)
 		if err != nil {
 			c.JSON(500, gin.H{"error": err.Error()})
 			return
 		}
 
-		log.Println(resp.Status)
-		log.Println(resp.Body)
+		log.Println(resp.Address)
 
 		c.JSON(200, gin.H{
-			"wallet_addr": resp.Body,
+			"wallet_addr": resp.Address,
 		})
 	})
 
-	router.Run(host + ":" + port)
+	if pool, err := nodePoolFromEnv(); err != nil {
+		log.Fatalf("Failed to configure node pool: %v", err)
+	} else if pool != nil {
+		registerNodeRoutes(context.Background(), router, pool)
+	}
+
+	addr := host + ":" + port
+	if os.Getenv("TLS_ENABLE") == "true" {
+		if err := runTLS(router, addr, tlsServerConfigFromEnv()); err != nil {
+			log.Fatalf("Failed to serve over TLS: %v", err)
+		}
+		return
+	}
+
+	router.Run(addr)
 }
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"xmr-be/rpc/prober"
+
+	gin "github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// defaultMaxLagBlocks is how far behind the tallest known peer a node may
+// fall before BestDaemon stops considering it for failover.
+const defaultMaxLagBlocks = 5
+
+// nodePoolFromEnv parses NODE_ENDPOINTS, a comma-separated list of
+// "name=host:port" entries (optionally "name=host:port:proxy" to route an
+// entry through a SOCKS5 proxy, for .onion nodes), and builds a
+// prober.Pool that checks them every NODE_PROBE_INTERVAL (default 30s) and
+// fails over away from nodes more than NODE_MAX_LAG_BLOCKS (default 5)
+// behind the tallest known peer.
+func nodePoolFromEnv() (*prober.Pool, error) {
+	raw := os.Getenv("NODE_ENDPOINTS")
+	if raw == "" {
+		return nil, nil
+	}
+
+	interval := 30 * time.Second
+	if v := os.Getenv("NODE_PROBE_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil {
+			interval = parsed
+		}
+	}
+
+	maxLagBlocks := uint64(defaultMaxLagBlocks)
+	if v := os.Getenv("NODE_MAX_LAG_BLOCKS"); v != "" {
+		if parsed, err := strconv.ParseUint(v, 10, 64); err == nil {
+			maxLagBlocks = parsed
+		}
+	}
+
+	endpoints := parseNodeEndpoints(raw)
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+
+	return prober.NewPool(endpoints, interval, maxLagBlocks)
+}
+
+// parseNodeEndpoints parses a comma-separated "name=host:port" list
+// (optionally "name=host:port:proxy") into prober.Endpoints, skipping any
+// entry that doesn't parse. Split out of nodePoolFromEnv so the parsing
+// logic can be unit-tested without touching the environment.
+func parseNodeEndpoints(raw string) []prober.Endpoint {
+	var endpoints []prober.Endpoint
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		nameAndAddr := strings.SplitN(entry, "=", 2)
+		if len(nameAndAddr) != 2 {
+			continue
+		}
+		name := nameAndAddr[0]
+
+		// Split into at most 3 pieces so a proxy URL's own colons (e.g.
+		// "socks5://127.0.0.1:9050") survive intact in the third piece
+		// instead of being shredded by a plain strings.Split.
+		parts := strings.SplitN(nameAndAddr[1], ":", 3)
+		if len(parts) < 2 {
+			continue
+		}
+
+		port, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+
+		endpoint := prober.Endpoint{Name: name, Address: parts[0], Port: port}
+		if len(parts) == 3 {
+			endpoint.Proxy = parts[2]
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+// registerNodeRoutes exposes /nodes (pool health), /metrics (Prometheus) and
+// /chain/info (a read RPC served through the pool with failover) on router,
+// and starts the pool's background probing loop.
+func registerNodeRoutes(ctx context.Context, router *gin.Engine, pool *prober.Pool) {
+	pool.Start(ctx)
+
+	router.GET("/nodes", func(c *gin.Context) {
+		c.JSON(200, gin.H{"nodes": pool.Statuses()})
+	})
+
+	router.GET("/chain/info", func(c *gin.Context) {
+		d, err := pool.BestDaemon()
+		if err != nil {
+			c.JSON(503, gin.H{"error": err.Error()})
+			return
+		}
+
+		info, err := d.GetInfo(c.Request.Context())
+		if err != nil {
+			c.JSON(502, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(200, info)
+	})
+
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"xmr-be/rpc/prober"
+)
+
+func TestParseNodeEndpoints(t *testing.T) {
+	raw := "alice=127.0.0.1:18081,torNode=abc123.onion:18081:socks5://127.0.0.1:9050,malformed"
+
+	got := parseNodeEndpoints(raw)
+
+	want := []prober.Endpoint{
+		{Name: "alice", Address: "127.0.0.1", Port: 18081},
+		{Name: "torNode", Address: "abc123.onion", Port: 18081, Proxy: "socks5://127.0.0.1:9050"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d endpoints, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("endpoint %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseNodeEndpointsProxyColonsSurvive(t *testing.T) {
+	// This is the specific regression this parser must not reintroduce:
+	// a naive strings.Split(..., ":") on the proxy portion would shred
+	// "socks5://127.0.0.1:9050" into several pieces and leave Proxy as
+	// the bare string "socks5".
+	got := parseNodeEndpoints("torNode=abc123.onion:18081:socks5://127.0.0.1:9050")
+
+	if len(got) != 1 {
+		t.Fatalf("got %d endpoints, want 1: %+v", len(got), got)
+	}
+	if want := "socks5://127.0.0.1:9050"; got[0].Proxy != want {
+		t.Errorf("Proxy = %q, want %q", got[0].Proxy, want)
+	}
+}
+
+func TestParseNodeEndpointsSkipsMalformedEntries(t *testing.T) {
+	got := parseNodeEndpoints("no-equals-sign,name=no-port,name=badport:notanumber")
+	if len(got) != 0 {
+		t.Fatalf("got %d endpoints, want 0: %+v", len(got), got)
+	}
+}
@@ -0,0 +1,307 @@
+// Package daemon provides typed methods for the monerod JSON-RPC API
+// (the `/json_rpc` endpoint exposed by monerod), layered on top of an
+// rpc.Client.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+
+	"xmr-be/rpc"
+)
+
+// Client provides typed access to the monerod JSON-RPC methods over an
+// underlying rpc.Client.
+type Client struct {
+	rpc *rpc.Client
+}
+
+// New wraps an existing rpc.Client with the typed monerod methods.
+func New(c *rpc.Client) *Client {
+	return &Client{rpc: c}
+}
+
+// GetInfoResult is the response of the `get_info` method.
+type GetInfoResult struct {
+	Height          uint64 `json:"height"`
+	TargetHeight    uint64 `json:"target_height"`
+	Difficulty      uint64 `json:"difficulty"`
+	TxCount         uint64 `json:"tx_count"`
+	TxPoolSize      uint64 `json:"tx_pool_size"`
+	AltBlocksCount  uint64 `json:"alt_blocks_count"`
+	OutgoingConns   int    `json:"outgoing_connections_count"`
+	IncomingConns   int    `json:"incoming_connections_count"`
+	WhitePeerlist   int    `json:"white_peerlist_size"`
+	GreyPeerlist    int    `json:"grey_peerlist_size"`
+	Mainnet         bool   `json:"mainnet"`
+	Testnet         bool   `json:"testnet"`
+	Stagenet        bool   `json:"stagenet"`
+	Nettype         string `json:"nettype"`
+	TopBlockHash    string `json:"top_block_hash"`
+	Synchronized    bool   `json:"synchronized"`
+	Offline         bool   `json:"offline"`
+	Version         string `json:"version"`
+	CumulativeDiff  uint64 `json:"cumulative_difficulty"`
+	FreeSpace       uint64 `json:"free_space"`
+	DatabaseSize    uint64 `json:"database_size"`
+	StartTime       int64  `json:"start_time"`
+	WasBootstrapped bool   `json:"was_bootstrap_ever_used"`
+}
+
+// GetInfo calls `get_info` and returns the daemon's current status.
+func (d *Client) GetInfo(ctx context.Context) (*GetInfoResult, error) {
+	var result GetInfoResult
+	if err := d.rpc.Call(ctx, "get_info", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetBlockCountResult is the response of the `get_block_count` method.
+type GetBlockCountResult struct {
+	Count  uint64 `json:"count"`
+	Status string `json:"status"`
+}
+
+// GetBlockCount calls `get_block_count` and returns the current chain height.
+func (d *Client) GetBlockCount(ctx context.Context) (*GetBlockCountResult, error) {
+	var result GetBlockCountResult
+	if err := d.rpc.Call(ctx, "get_block_count", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetBlockParams are the accepted parameters for `get_block`: either Height
+// or Hash should be set.
+type GetBlockParams struct {
+	Height uint64 `json:"height,omitempty"`
+	Hash   string `json:"hash,omitempty"`
+}
+
+// GetBlockResult is the response of the `get_block` method.
+type GetBlockResult struct {
+	Blob        string      `json:"blob"`
+	BlockHeader BlockHeader `json:"block_header"`
+	Json        string      `json:"json"`
+	MinerTxHash string      `json:"miner_tx_hash"`
+	Status      string      `json:"status"`
+}
+
+// GetBlock calls `get_block` for the given height or hash.
+func (d *Client) GetBlock(ctx context.Context, params GetBlockParams) (*GetBlockResult, error) {
+	var result GetBlockResult
+	if err := d.rpc.Call(ctx, "get_block", toParams(params), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BlockHeader is the common header shape returned by `get_block`,
+// `get_block_header_by_hash` and `get_block_header_by_height`.
+type BlockHeader struct {
+	BlockSize    uint64 `json:"block_size"`
+	Depth        uint64 `json:"depth"`
+	Difficulty   uint64 `json:"difficulty"`
+	Hash         string `json:"hash"`
+	Height       uint64 `json:"height"`
+	MajorVersion uint64 `json:"major_version"`
+	MinorVersion uint64 `json:"minor_version"`
+	Nonce        uint64 `json:"nonce"`
+	NumTxes      uint64 `json:"num_txes"`
+	OrphanStatus bool   `json:"orphan_status"`
+	PrevHash     string `json:"prev_hash"`
+	Reward       uint64 `json:"reward"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+// GetBlockHeaderByHashResult is the response of `get_block_header_by_hash`.
+type GetBlockHeaderByHashResult struct {
+	BlockHeader BlockHeader `json:"block_header"`
+	Status      string      `json:"status"`
+}
+
+// GetBlockHeaderByHash calls `get_block_header_by_hash` for the given block hash.
+func (d *Client) GetBlockHeaderByHash(ctx context.Context, hash string) (*GetBlockHeaderByHashResult, error) {
+	var result GetBlockHeaderByHashResult
+	if err := d.rpc.Call(ctx, "get_block_header_by_hash", map[string]interface{}{"hash": hash}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetBlockHeaderByHeightResult is the response of `get_block_header_by_height`.
+type GetBlockHeaderByHeightResult struct {
+	BlockHeader BlockHeader `json:"block_header"`
+	Status      string      `json:"status"`
+}
+
+// GetBlockHeaderByHeight calls `get_block_header_by_height` for the given height.
+func (d *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*GetBlockHeaderByHeightResult, error) {
+	var result GetBlockHeaderByHeightResult
+	if err := d.rpc.Call(ctx, "get_block_header_by_height", map[string]interface{}{"height": height}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OnGetBlockHashResult is the response of `on_get_block_hash`, a single
+// block hash string.
+type OnGetBlockHashResult string
+
+// OnGetBlockHash calls `on_get_block_hash` for the given height.
+// Unlike the other daemon methods, `on_get_block_hash` takes its
+// parameters as a positional JSON array ([height]) rather than a named
+// object.
+func (d *Client) OnGetBlockHash(ctx context.Context, height uint64) (OnGetBlockHashResult, error) {
+	var result OnGetBlockHashResult
+	if err := d.rpc.Call(ctx, "on_get_block_hash", []interface{}{height}, &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// GetTransactionsResult is the response of the `get_transactions` call.
+// Unlike the other daemon methods, `get_transactions` is not a /json_rpc
+// method at all - it's a plain REST endpoint at /get_transactions - so it
+// is issued via rpc.Client.PostJSON rather than rpc.Client.Call.
+type GetTransactionsResult struct {
+	Txs       []TxEntry `json:"txs"`
+	TxsAsHex  []string  `json:"txs_as_hex"`
+	TxsAsJson []string  `json:"txs_as_json"`
+	Status    string    `json:"status"`
+}
+
+// TxEntry describes a single transaction entry in a `get_transactions` response.
+type TxEntry struct {
+	TxHash         string `json:"tx_hash"`
+	AsHex          string `json:"as_hex"`
+	AsJson         string `json:"as_json"`
+	InPool         bool   `json:"in_pool"`
+	BlockHeight    uint64 `json:"block_height"`
+	BlockTimestamp int64  `json:"block_timestamp"`
+	Confirmations  uint64 `json:"confirmations"`
+}
+
+// GetTransactions calls `get_transactions` for the given tx hashes.
+func (d *Client) GetTransactions(ctx context.Context, txHashes []string, decodeAsJson bool) (*GetTransactionsResult, error) {
+	var result GetTransactionsResult
+	params := map[string]interface{}{
+		"txs_hashes":     txHashes,
+		"decode_as_json": decodeAsJson,
+	}
+	if err := d.rpc.PostJSON(ctx, "/get_transactions", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTransactionPoolResult is the response of `get_transaction_pool`.
+type GetTransactionPoolResult struct {
+	Transactions []TxEntry `json:"transactions"`
+	Status       string    `json:"status"`
+}
+
+// GetTransactionPool calls `get_transaction_pool`, a plain REST endpoint
+// at /get_transaction_pool rather than a /json_rpc method.
+func (d *Client) GetTransactionPool(ctx context.Context) (*GetTransactionPoolResult, error) {
+	var result GetTransactionPoolResult
+	if err := d.rpc.PostJSON(ctx, "/get_transaction_pool", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SendRawTransactionResult is the response of `send_raw_transaction`.
+type SendRawTransactionResult struct {
+	Status       string `json:"status"`
+	DoubleSpend  bool   `json:"double_spend"`
+	FeeTooLow    bool   `json:"fee_too_low"`
+	InvalidInput bool   `json:"invalid_input"`
+	NotRelayed   bool   `json:"not_relayed"`
+	Reason       string `json:"reason"`
+	TooBig       bool   `json:"too_big"`
+}
+
+// SendRawTransaction calls `send_raw_transaction` with a hex-encoded tx
+// blob, a plain REST endpoint at /send_raw_transaction rather than a
+// /json_rpc method.
+func (d *Client) SendRawTransaction(ctx context.Context, txAsHex string, doNotRelay bool) (*SendRawTransactionResult, error) {
+	var result SendRawTransactionResult
+	params := map[string]interface{}{
+		"tx_as_hex":    txAsHex,
+		"do_not_relay": doNotRelay,
+	}
+	if err := d.rpc.PostJSON(ctx, "/send_raw_transaction", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFeeEstimateResult is the response of `get_fee_estimate`.
+type GetFeeEstimateResult struct {
+	Fee          uint64 `json:"fee"`
+	Quantization uint64 `json:"quantization_mask"`
+	Status       string `json:"status"`
+}
+
+// GetFeeEstimate calls `get_fee_estimate`.
+func (d *Client) GetFeeEstimate(ctx context.Context) (*GetFeeEstimateResult, error) {
+	var result GetFeeEstimateResult
+	if err := d.rpc.Call(ctx, "get_fee_estimate", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncInfoResult is the response of `sync_info`.
+type SyncInfoResult struct {
+	Height       uint64 `json:"height"`
+	TargetHeight uint64 `json:"target_height"`
+	Status       string `json:"status"`
+}
+
+// SyncInfo calls `sync_info`.
+func (d *Client) SyncInfo(ctx context.Context) (*SyncInfoResult, error) {
+	var result SyncInfoResult
+	if err := d.rpc.Call(ctx, "sync_info", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// HardForkInfoResult is the response of `hard_fork_info`.
+type HardForkInfoResult struct {
+	EarliestHeight uint64 `json:"earliest_height"`
+	Enabled        bool   `json:"enabled"`
+	State          uint64 `json:"state"`
+	Threshold      uint64 `json:"threshold"`
+	Version        uint64 `json:"version"`
+	Votes          uint64 `json:"votes"`
+	Voting         uint64 `json:"voting"`
+	Window         uint64 `json:"window"`
+	Status         string `json:"status"`
+}
+
+// HardForkInfo calls `hard_fork_info`.
+func (d *Client) HardForkInfo(ctx context.Context) (*HardForkInfoResult, error) {
+	var result HardForkInfoResult
+	if err := d.rpc.Call(ctx, "hard_fork_info", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// toParams converts a typed params struct into the map[string]interface{}
+// shape rpc.Call expects by round-tripping it through JSON.
+func toParams(v interface{}) map[string]interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
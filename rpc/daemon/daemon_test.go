@@ -0,0 +1,102 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"xmr-be/rpc"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("failed to parse test server port: %v", err)
+	}
+
+	rpcClient, err := rpc.NewClient(rpc.Config{Address: u.Hostname(), Port: port})
+	if err != nil {
+		t.Fatalf("rpc.NewClient: %v", err)
+	}
+	return New(rpcClient)
+}
+
+// TestGetTransactionsHitsRESTEndpoint verifies GetTransactions is sent to
+// its own plain REST path, /get_transactions, not /json_rpc - it is not a
+// JSON-RPC method on a real monerod. GetTransactionPool and
+// SendRawTransaction take the same PostJSON route but are not exercised
+// here.
+func TestGetTransactionsHitsRESTEndpoint(t *testing.T) {
+	var sawPath string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sawPath = r.URL.Path
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if _, ok := body["txs_hashes"]; !ok {
+			t.Errorf("request body missing txs_hashes: %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(GetTransactionsResult{Status: "OK"})
+	})
+
+	result, err := client.GetTransactions(context.Background(), []string{"abc123"}, true)
+	if err != nil {
+		t.Fatalf("GetTransactions: %v", err)
+	}
+
+	if sawPath != "/get_transactions" {
+		t.Errorf("request path = %q, want /get_transactions", sawPath)
+	}
+	if result.Status != "OK" {
+		t.Errorf("result.Status = %q, want OK", result.Status)
+	}
+}
+
+// TestGetInfoErrorEnvelope verifies a /json_rpc {"error":{...}} response
+// surfaces as a *rpc.RPCError with the right code/message - the headline
+// promise of the typed-client rewrite ("proper error decoding of the
+// JSON-RPC error envelope").
+func TestGetInfoErrorEnvelope(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    -32601,
+				"message": "Method not found",
+			},
+		})
+	})
+
+	_, err := client.GetInfo(context.Background())
+	if err == nil {
+		t.Fatal("GetInfo: expected an error, got nil")
+	}
+
+	rpcErr, ok := err.(*rpc.RPCError)
+	if !ok {
+		t.Fatalf("GetInfo error = %T (%v), want *rpc.RPCError", err, err)
+	}
+	if rpcErr.Code != -32601 {
+		t.Errorf("rpcErr.Code = %d, want -32601", rpcErr.Code)
+	}
+	if rpcErr.Message != "Method not found" {
+		t.Errorf("rpcErr.Message = %q, want %q", rpcErr.Message, "Method not found")
+	}
+}
@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// digestChallenge holds the parameters monero-wallet-rpc sends in a
+// `WWW-Authenticate: Digest ...` challenge.
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	qop       string
+	algorithm string
+	stale     bool
+}
+
+func isDigestChallenge(wwwAuthenticate string) bool {
+	return strings.HasPrefix(strings.TrimSpace(wwwAuthenticate), "Digest")
+}
+
+// parseDigestChallenge parses the key="value" pairs out of a
+// `WWW-Authenticate: Digest ...` header.
+func parseDigestChallenge(wwwAuthenticate string) (*digestChallenge, error) {
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(wwwAuthenticate), "Digest"))
+
+	params := map[string]string{}
+	for _, part := range splitDigestParams(rest) {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(kv[0])
+		value := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		params[key] = value
+	}
+
+	if params["realm"] == "" || params["nonce"] == "" {
+		return nil, fmt.Errorf("rpc: malformed digest challenge: %q", wwwAuthenticate)
+	}
+
+	algorithm := params["algorithm"]
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	return &digestChallenge{
+		realm:     params["realm"],
+		nonce:     params["nonce"],
+		opaque:    params["opaque"],
+		qop:       firstQop(params["qop"]),
+		algorithm: algorithm,
+		stale:     strings.EqualFold(params["stale"], "true"),
+	}, nil
+}
+
+// splitDigestParams splits a comma-separated "k=v, k=\"v,with,commas\""
+// list on top-level commas, ignoring commas inside quoted values.
+func splitDigestParams(s string) []string {
+	var parts []string
+	var inQuotes bool
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+	return parts
+}
+
+// firstQop picks the first supported qop value out of a possibly
+// comma-separated qop-options list (e.g. "auth,auth-int").
+func firstQop(qop string) string {
+	for _, v := range strings.Split(qop, ",") {
+		v = strings.TrimSpace(v)
+		if v == "auth" || v == "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (c *Client) cachedDigestChallenge() *digestChallenge {
+	c.digestMu.Lock()
+	defer c.digestMu.Unlock()
+	return c.digestChallenge
+}
+
+func (c *Client) setCachedDigestChallenge(challenge *digestChallenge) {
+	c.digestMu.Lock()
+	defer c.digestMu.Unlock()
+	c.digestChallenge = challenge
+	c.digestNonceUsed = 0
+}
+
+// setDigestAuth computes and sets an `Authorization: Digest ...` header on
+// req for the given challenge, per RFC 2617: HA1 = MD5(user:realm:pass),
+// HA2 = MD5(method:uri), response = MD5(HA1:nonce:nc:cnonce:qop:HA2).
+func (c *Client) setDigestAuth(req *http.Request, challenge *digestChallenge) error {
+	cnonce, err := randomHex(8)
+	if err != nil {
+		return err
+	}
+
+	c.digestMu.Lock()
+	c.digestNonceUsed++
+	nc := fmt.Sprintf("%08x", c.digestNonceUsed)
+	c.digestMu.Unlock()
+
+	ha1 := md5Hex(c.username + ":" + challenge.realm + ":" + c.password)
+	ha2 := md5Hex(req.Method + ":" + req.URL.RequestURI())
+
+	var response, qopField string
+	if challenge.qop == "auth" {
+		response = md5Hex(strings.Join([]string{ha1, challenge.nonce, nc, cnonce, challenge.qop, ha2}, ":"))
+		qopField = fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, challenge.qop, nc, cnonce)
+	} else {
+		response = md5Hex(strings.Join([]string{ha1, challenge.nonce, ha2}, ":"))
+	}
+
+	header := fmt.Sprintf(
+		`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s", algorithm=%s`,
+		c.username, challenge.realm, challenge.nonce, req.URL.RequestURI(), response, challenge.algorithm,
+	)
+	header += qopField
+	if challenge.opaque != "" {
+		header += fmt.Sprintf(`, opaque="%s"`, challenge.opaque)
+	}
+
+	req.Header.Set("Authorization", header)
+	return nil
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
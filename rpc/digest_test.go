@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestClientDigestAuthChallengeAndRetry(t *testing.T) {
+	var requestCount int
+	var authHeaderOnRetry string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Digest ") {
+			w.Header().Set("WWW-Authenticate", `Digest realm="monero-rpc", nonce="testnonce", qop="auth"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		authHeaderOnRetry = auth
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(MoneroRPCResponse{
+			Result: map[string]interface{}{"status": "OK"},
+		})
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("failed to parse test server port: %v", err)
+	}
+
+	client, err := NewClient(Config{
+		Address:  u.Hostname(),
+		Port:     port,
+		Username: "rpcuser",
+		Password: "rpcpass",
+		AuthMode: AuthDigest,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := client.Call(context.Background(), "get_info", map[string]interface{}{}, &result); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+
+	if requestCount != 2 {
+		t.Errorf("requestCount = %d, want 2 (one challenge, one authenticated retry)", requestCount)
+	}
+	if !strings.Contains(authHeaderOnRetry, `nonce="testnonce"`) {
+		t.Errorf("retry Authorization header missing challenge nonce: %q", authHeaderOnRetry)
+	}
+	if !strings.Contains(authHeaderOnRetry, `username="rpcuser"`) {
+		t.Errorf("retry Authorization header missing username: %q", authHeaderOnRetry)
+	}
+	if result["status"] != "OK" {
+		t.Errorf("result[status] = %v, want OK", result["status"])
+	}
+}
+
+func TestClientDigestAuthCachesChallenge(t *testing.T) {
+	var requestCount int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Digest ") {
+			w.Header().Set("WWW-Authenticate", `Digest realm="monero-rpc", nonce="testnonce", qop="auth"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(MoneroRPCResponse{
+			Result: map[string]interface{}{"status": "OK"},
+		})
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("failed to parse test server port: %v", err)
+	}
+
+	client, err := NewClient(Config{
+		Address:  u.Hostname(),
+		Port:     port,
+		Username: "rpcuser",
+		Password: "rpcpass",
+		AuthMode: AuthDigest,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := client.Call(ctx, "get_info", map[string]interface{}{}, nil); err != nil {
+		t.Fatalf("first Call: %v", err)
+	}
+	if err := client.Call(ctx, "get_info", map[string]interface{}{}, nil); err != nil {
+		t.Fatalf("second Call: %v", err)
+	}
+
+	// First call: challenge + authenticated retry (2 requests). Second
+	// call should reuse the cached challenge and only need 1 request.
+	if requestCount != 3 {
+		t.Errorf("requestCount = %d, want 3 (challenge+retry, then one cached request)", requestCount)
+	}
+}
@@ -0,0 +1,272 @@
+// Package prober periodically health-checks a list of monerod endpoints
+// and exposes their status, so callers can fail over reads away from a
+// node that is unreachable or has fallen behind the chain tip.
+package prober
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"xmr-be/rpc"
+	"xmr-be/rpc/daemon"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Endpoint describes one monerod instance to probe. Proxy is optional and
+// only needed for .onion addresses.
+type Endpoint struct {
+	Name    string
+	Address string
+	Port    int
+	Secure  bool
+	Proxy   string
+}
+
+// Status is the most recent probe result for one Endpoint.
+type Status struct {
+	Name            string    `json:"name"`
+	Height          uint64    `json:"height"`
+	TargetHeight    uint64    `json:"target_height"`
+	Synchronized    bool      `json:"synchronized"`
+	Offline         bool      `json:"offline"`
+	Nettype         string    `json:"nettype"`
+	Version         string    `json:"version"`
+	HardForkVersion uint64    `json:"hard_fork_version"`
+	Latency         float64   `json:"latency_seconds"`
+	LastChecked     time.Time `json:"last_checked"`
+	Err             string    `json:"error,omitempty"`
+}
+
+// Healthy reports whether the node answered its last probe without error
+// and isn't flagged offline.
+func (s Status) Healthy() bool {
+	return s.Err == "" && !s.Offline
+}
+
+var (
+	metricHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "xmr_be",
+		Subsystem: "prober",
+		Name:      "node_height",
+		Help:      "Last observed blockchain height of a probed node.",
+	}, []string{"node"})
+
+	metricTargetHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "xmr_be",
+		Subsystem: "prober",
+		Name:      "node_target_height",
+		Help:      "Last observed sync target height of a probed node.",
+	}, []string{"node"})
+
+	metricSynchronized = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "xmr_be",
+		Subsystem: "prober",
+		Name:      "node_synchronized",
+		Help:      "Whether the probed node reported itself as synchronized (1) or not (0).",
+	}, []string{"node"})
+
+	metricUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "xmr_be",
+		Subsystem: "prober",
+		Name:      "node_up",
+		Help:      "Whether the last probe of this node succeeded (1) or failed (0).",
+	}, []string{"node"})
+
+	metricLatency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "xmr_be",
+		Subsystem: "prober",
+		Name:      "node_probe_latency_seconds",
+		Help:      "Latency of the last get_info probe against this node.",
+	}, []string{"node"})
+
+	metricHardForkVersion = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "xmr_be",
+		Subsystem: "prober",
+		Name:      "node_hard_fork_version",
+		Help:      "Last observed hard_fork_info version of a probed node.",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(metricHeight, metricTargetHeight, metricSynchronized, metricUp, metricLatency, metricHardForkVersion)
+}
+
+type node struct {
+	endpoint Endpoint
+	daemon   *daemon.Client
+}
+
+// Pool probes a fixed set of monerod endpoints on a timer and keeps their
+// latest Status in memory.
+type Pool struct {
+	interval     time.Duration
+	maxLagBlocks uint64
+
+	mu       sync.RWMutex
+	nodes    []*node
+	statuses map[string]Status
+}
+
+// NewPool builds a Pool for the given endpoints, probing every interval.
+// maxLagBlocks is the threshold BestDaemon uses to reject nodes that have
+// fallen too far behind the tallest known peer.
+func NewPool(endpoints []Endpoint, interval time.Duration, maxLagBlocks uint64) (*Pool, error) {
+	p := &Pool{
+		interval:     interval,
+		maxLagBlocks: maxLagBlocks,
+		statuses:     make(map[string]Status, len(endpoints)),
+	}
+
+	for _, ep := range endpoints {
+		client, err := rpc.NewClient(rpc.Config{
+			Address: ep.Address,
+			Port:    ep.Port,
+			Secure:  ep.Secure,
+			Proxy:   ep.Proxy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prober: failed to build client for %s: %w", ep.Name, err)
+		}
+		p.nodes = append(p.nodes, &node{endpoint: ep, daemon: daemon.New(client)})
+	}
+
+	return p, nil
+}
+
+// Start probes every node once immediately, then on every tick of the
+// configured interval, until ctx is cancelled.
+func (p *Pool) Start(ctx context.Context) {
+	p.probeAll(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.probeAll(ctx)
+			}
+		}
+	}()
+}
+
+func (p *Pool) probeAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			p.probeOne(ctx, n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (p *Pool) probeOne(ctx context.Context, n *node) {
+	start := time.Now()
+	info, err := n.daemon.GetInfo(ctx)
+	latency := time.Since(start).Seconds()
+
+	status := Status{
+		Name:        n.endpoint.Name,
+		Latency:     latency,
+		LastChecked: start,
+	}
+
+	if err != nil {
+		status.Err = err.Error()
+	} else {
+		status.Height = info.Height
+		status.TargetHeight = info.TargetHeight
+		status.Synchronized = info.Synchronized
+		status.Offline = info.Offline
+		status.Nettype = info.Nettype
+		status.Version = info.Version
+
+		// sync_info and hard_fork_info are supplementary to get_info's core
+		// health signal, so a failure here doesn't flip the node unhealthy -
+		// it just leaves TargetHeight/HardForkVersion at their get_info (or
+		// zero) value.
+		if syncInfo, err := n.daemon.SyncInfo(ctx); err == nil && syncInfo.TargetHeight > status.TargetHeight {
+			status.TargetHeight = syncInfo.TargetHeight
+		}
+		if hardFork, err := n.daemon.HardForkInfo(ctx); err == nil {
+			status.HardForkVersion = hardFork.Version
+		}
+	}
+
+	p.mu.Lock()
+	p.statuses[n.endpoint.Name] = status
+	p.mu.Unlock()
+
+	upValue := 0.0
+	if status.Healthy() {
+		upValue = 1.0
+	}
+	metricUp.WithLabelValues(n.endpoint.Name).Set(upValue)
+	metricHeight.WithLabelValues(n.endpoint.Name).Set(float64(status.Height))
+	metricTargetHeight.WithLabelValues(n.endpoint.Name).Set(float64(status.TargetHeight))
+	metricLatency.WithLabelValues(n.endpoint.Name).Set(latency)
+	metricHardForkVersion.WithLabelValues(n.endpoint.Name).Set(float64(status.HardForkVersion))
+	if status.Synchronized {
+		metricSynchronized.WithLabelValues(n.endpoint.Name).Set(1)
+	} else {
+		metricSynchronized.WithLabelValues(n.endpoint.Name).Set(0)
+	}
+}
+
+// Statuses returns the latest known status of every node in the pool.
+func (p *Pool) Statuses() []Status {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(p.statuses))
+	for _, s := range p.statuses {
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// BestDaemon returns the daemon.Client for the healthy node with the
+// highest chain height, failing over away from nodes that are
+// unreachable or more than p.maxLagBlocks behind the tallest known peer.
+// It returns an error if no node in the pool is currently healthy.
+func (p *Pool) BestDaemon() (*daemon.Client, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var best *node
+	var bestHeight uint64
+	for _, n := range p.nodes {
+		status, ok := p.statuses[n.endpoint.Name]
+		if !ok || !status.Healthy() {
+			continue
+		}
+		if status.Height > bestHeight {
+			bestHeight = status.Height
+		}
+	}
+
+	for _, n := range p.nodes {
+		status, ok := p.statuses[n.endpoint.Name]
+		if !ok || !status.Healthy() {
+			continue
+		}
+		if bestHeight-status.Height > p.maxLagBlocks {
+			continue
+		}
+		if best == nil || status.Height > p.statuses[best.endpoint.Name].Height {
+			best = n
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("prober: no healthy node available")
+	}
+	return best.daemon, nil
+}
@@ -0,0 +1,130 @@
+package prober
+
+import (
+	"testing"
+
+	"xmr-be/rpc"
+	"xmr-be/rpc/daemon"
+)
+
+// newTestPool builds a Pool with one node per name in names, wired with a
+// throwaway daemon.Client (BestDaemon never issues an RPC, it only picks
+// between nodes using the Pool's cached statuses) and the given statuses
+// pre-seeded, bypassing NewPool/probeOne so tests can pin exact status
+// combinations.
+func newTestPool(t *testing.T, maxLagBlocks uint64, statuses map[string]Status) *Pool {
+	t.Helper()
+
+	p := &Pool{
+		maxLagBlocks: maxLagBlocks,
+		statuses:     statuses,
+	}
+	for name := range statuses {
+		client, err := rpc.NewClient(rpc.Config{Address: "127.0.0.1", Port: 18081})
+		if err != nil {
+			t.Fatalf("rpc.NewClient: %v", err)
+		}
+		p.nodes = append(p.nodes, &node{
+			endpoint: Endpoint{Name: name},
+			daemon:   daemon.New(client),
+		})
+	}
+	return p
+}
+
+func TestBestDaemonPicksTallestHealthyNode(t *testing.T) {
+	p := newTestPool(t, 5, map[string]Status{
+		"alice": {Name: "alice", Height: 100},
+		"bob":   {Name: "bob", Height: 105},
+		"carol": {Name: "carol", Height: 103},
+	})
+
+	d, err := p.BestDaemon()
+	if err != nil {
+		t.Fatalf("BestDaemon: %v", err)
+	}
+
+	var want *daemon.Client
+	for _, n := range p.nodes {
+		if n.endpoint.Name == "bob" {
+			want = n.daemon
+		}
+	}
+	if d != want {
+		t.Errorf("BestDaemon returned a different client than bob's")
+	}
+}
+
+func TestBestDaemonSkipsUnhealthyNodes(t *testing.T) {
+	p := newTestPool(t, 5, map[string]Status{
+		"alice": {Name: "alice", Height: 200, Err: "connection refused"},
+		"bob":   {Name: "bob", Height: 100},
+	})
+
+	d, err := p.BestDaemon()
+	if err != nil {
+		t.Fatalf("BestDaemon: %v", err)
+	}
+
+	var want *daemon.Client
+	for _, n := range p.nodes {
+		if n.endpoint.Name == "bob" {
+			want = n.daemon
+		}
+	}
+	if d != want {
+		t.Errorf("BestDaemon should have failed over to bob, the only healthy node")
+	}
+}
+
+func TestBestDaemonSkipsLaggingNodes(t *testing.T) {
+	p := newTestPool(t, 5, map[string]Status{
+		"alice": {Name: "alice", Height: 200},
+		"bob":   {Name: "bob", Height: 190}, // 10 blocks behind, over maxLagBlocks=5
+	})
+
+	d, err := p.BestDaemon()
+	if err != nil {
+		t.Fatalf("BestDaemon: %v", err)
+	}
+
+	var want *daemon.Client
+	for _, n := range p.nodes {
+		if n.endpoint.Name == "alice" {
+			want = n.daemon
+		}
+	}
+	if d != want {
+		t.Errorf("BestDaemon should have picked alice and skipped lagging bob")
+	}
+}
+
+func TestBestDaemonNoHealthyNodes(t *testing.T) {
+	p := newTestPool(t, 5, map[string]Status{
+		"alice": {Name: "alice", Err: "timeout"},
+		"bob":   {Name: "bob", Offline: true},
+	})
+
+	if _, err := p.BestDaemon(); err == nil {
+		t.Fatal("BestDaemon: expected an error when no node is healthy, got nil")
+	}
+}
+
+func TestStatusHealthy(t *testing.T) {
+	cases := []struct {
+		name   string
+		status Status
+		want   bool
+	}{
+		{"healthy", Status{}, true},
+		{"errored", Status{Err: "timeout"}, false},
+		{"offline", Status{Offline: true}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.status.Healthy(); got != tc.want {
+				t.Errorf("Healthy() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"golang.org/x/net/proxy"
+)
+
+// newProxyDialContext builds an http.Transport-compatible DialContext that
+// routes connections through the SOCKS5 proxy described by proxyURL (e.g.
+// "socks5://127.0.0.1:9050", the default Tor SOCKSPort). This is what lets
+// a Client reach .onion monerod/wallet-rpc endpoints.
+//
+// The hostname is handed to the proxy unresolved - net/http never resolves
+// the address before calling DialContext, so .onion names are never looked
+// up against the system resolver; the Tor daemon resolves them itself.
+func newProxyDialContext(proxyURL string) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("rpc: invalid proxy URL %q: %w", proxyURL, err)
+	}
+	if parsed.Scheme != "socks5" {
+		return nil, fmt.Errorf("rpc: unsupported proxy scheme %q (only socks5 is supported)", parsed.Scheme)
+	}
+
+	var auth *proxy.Auth
+	if parsed.User != nil {
+		password, _ := parsed.User.Password()
+		auth = &proxy.Auth{User: parsed.User.Username(), Password: password}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", parsed.Host, auth, proxy.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("rpc: failed to build SOCKS5 dialer: %w", err)
+	}
+
+	if ctxDialer, ok := dialer.(proxy.ContextDialer); ok {
+		return ctxDialer.DialContext, nil
+	}
+
+	// Older proxy.Dialer implementations don't support contexts; fall back
+	// to a plain Dial, which can't be cancelled mid-connect.
+	return func(_ context.Context, network, addr string) (net.Conn, error) {
+		return dialer.Dial(network, addr)
+	}, nil
+}
@@ -0,0 +1,235 @@
+// Package wallet provides typed methods for the monero-wallet-rpc JSON-RPC
+// API (the `/json_rpc` endpoint exposed by monero-wallet-rpc), layered on
+// top of an rpc.Client.
+package wallet
+
+import (
+	"context"
+
+	"xmr-be/rpc"
+)
+
+// Client provides typed access to the monero-wallet-rpc JSON-RPC methods
+// over an underlying rpc.Client.
+type Client struct {
+	rpc *rpc.Client
+}
+
+// New wraps an existing rpc.Client with the typed wallet-rpc methods.
+func New(c *rpc.Client) *Client {
+	return &Client{rpc: c}
+}
+
+// GetBalanceResult is the response of `get_balance`.
+type GetBalanceResult struct {
+	Balance              uint64           `json:"balance"`
+	UnlockedBalance      uint64           `json:"unlocked_balance"`
+	MultisigImportNeeded bool             `json:"multisig_import_needed"`
+	PerSubaddress        []SubaddressInfo `json:"per_subaddress"`
+}
+
+// SubaddressInfo describes a single subaddress balance entry.
+type SubaddressInfo struct {
+	AddressIndex      uint64 `json:"address_index"`
+	Address           string `json:"address"`
+	Balance           uint64 `json:"balance"`
+	UnlockedBalance   uint64 `json:"unlocked_balance"`
+	Label             string `json:"label"`
+	NumUnspentOutputs uint64 `json:"num_unspent_outputs"`
+}
+
+// GetBalance calls `get_balance` for the given account index.
+func (w *Client) GetBalance(ctx context.Context, accountIndex uint64) (*GetBalanceResult, error) {
+	var result GetBalanceResult
+	params := map[string]interface{}{"account_index": accountIndex}
+	if err := w.rpc.Call(ctx, "get_balance", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAddressResult is the response of `get_address`.
+type GetAddressResult struct {
+	Address   string      `json:"address"`
+	Addresses []Addresses `json:"addresses"`
+}
+
+// Addresses describes a single address entry in a `get_address` response.
+type Addresses struct {
+	Address      string `json:"address"`
+	AddressIndex uint64 `json:"address_index"`
+	Label        string `json:"label"`
+	Used         bool   `json:"used"`
+}
+
+// GetAddress calls `get_address` for the given account index.
+func (w *Client) GetAddress(ctx context.Context, accountIndex uint64) (*GetAddressResult, error) {
+	var result GetAddressResult
+	params := map[string]interface{}{"account_index": accountIndex}
+	if err := w.rpc.Call(ctx, "get_address", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAddressResult is the response of `create_address`.
+type CreateAddressResult struct {
+	Address      string `json:"address"`
+	AddressIndex uint64 `json:"address_index"`
+}
+
+// CreateAddress calls `create_address` for the given account index.
+func (w *Client) CreateAddress(ctx context.Context, accountIndex uint64, label string) (*CreateAddressResult, error) {
+	var result CreateAddressResult
+	params := map[string]interface{}{
+		"account_index": accountIndex,
+		"label":         label,
+	}
+	if err := w.rpc.Call(ctx, "create_address", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Transfer describes a single entry in a `get_transfers` response.
+type Transfer struct {
+	Address         string `json:"address"`
+	Amount          uint64 `json:"amount"`
+	Confirmations   uint64 `json:"confirmations"`
+	DoubleSpendSeen bool   `json:"double_spend_seen"`
+	Fee             uint64 `json:"fee"`
+	Height          uint64 `json:"height"`
+	Note            string `json:"note"`
+	Timestamp       int64  `json:"timestamp"`
+	TxHash          string `json:"txid"`
+	Type            string `json:"type"`
+	UnlockTime      uint64 `json:"unlock_time"`
+}
+
+// GetTransfersResult is the response of `get_transfers`.
+type GetTransfersResult struct {
+	In      []Transfer `json:"in"`
+	Out     []Transfer `json:"out"`
+	Pending []Transfer `json:"pending"`
+	Pool    []Transfer `json:"pool"`
+	Failed  []Transfer `json:"failed"`
+}
+
+// GetTransfersParams mirrors the filters accepted by `get_transfers`.
+type GetTransfersParams struct {
+	In           bool   `json:"in,omitempty"`
+	Out          bool   `json:"out,omitempty"`
+	Pending      bool   `json:"pending,omitempty"`
+	Failed       bool   `json:"failed,omitempty"`
+	Pool         bool   `json:"pool,omitempty"`
+	AccountIndex uint64 `json:"account_index,omitempty"`
+}
+
+// GetTransfers calls `get_transfers`.
+func (w *Client) GetTransfers(ctx context.Context, params GetTransfersParams) (*GetTransfersResult, error) {
+	var result GetTransfersResult
+	p := map[string]interface{}{
+		"in":            params.In,
+		"out":           params.Out,
+		"pending":       params.Pending,
+		"failed":        params.Failed,
+		"pool":          params.Pool,
+		"account_index": params.AccountIndex,
+	}
+	if err := w.rpc.Call(ctx, "get_transfers", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Destination is a single transfer destination (amount, address).
+type Destination struct {
+	Amount  uint64 `json:"amount"`
+	Address string `json:"address"`
+}
+
+// TransferResult is the response of `transfer`.
+type TransferResult struct {
+	Fee        uint64 `json:"fee"`
+	TxHash     string `json:"tx_hash"`
+	TxKey      string `json:"tx_key"`
+	TxBlob     string `json:"tx_blob"`
+	TxMetadata string `json:"tx_metadata"`
+	Amount     uint64 `json:"amount"`
+}
+
+// Transfer calls `transfer` to send funds to one or more destinations.
+func (w *Client) Transfer(ctx context.Context, destinations []Destination, accountIndex uint64, priority uint64) (*TransferResult, error) {
+	var result TransferResult
+	params := map[string]interface{}{
+		"destinations":  destinations,
+		"account_index": accountIndex,
+		"priority":      priority,
+		"get_tx_key":    true,
+	}
+	if err := w.rpc.Call(ctx, "transfer", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MakeIntegratedAddressResult is the response of `make_integrated_address`.
+type MakeIntegratedAddressResult struct {
+	IntegratedAddress string `json:"integrated_address"`
+	PaymentID         string `json:"payment_id"`
+}
+
+// MakeIntegratedAddress calls `make_integrated_address`.
+func (w *Client) MakeIntegratedAddress(ctx context.Context, standardAddress, paymentID string) (*MakeIntegratedAddressResult, error) {
+	var result MakeIntegratedAddressResult
+	params := map[string]interface{}{
+		"standard_address": standardAddress,
+		"payment_id":       paymentID,
+	}
+	if err := w.rpc.Call(ctx, "make_integrated_address", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OpenWallet calls `open_wallet` to switch the active wallet file.
+func (w *Client) OpenWallet(ctx context.Context, filename, password string) error {
+	params := map[string]interface{}{
+		"filename": filename,
+		"password": password,
+	}
+	return w.rpc.Call(ctx, "open_wallet", params, nil)
+}
+
+// CreateWalletParams mirrors the accepted parameters for `create_wallet`.
+type CreateWalletParams struct {
+	Filename string `json:"filename"`
+	Password string `json:"password"`
+	Language string `json:"language"`
+}
+
+// CreateWallet calls `create_wallet` to create a new wallet file.
+func (w *Client) CreateWallet(ctx context.Context, params CreateWalletParams) error {
+	p := map[string]interface{}{
+		"filename": params.Filename,
+		"password": params.Password,
+		"language": params.Language,
+	}
+	return w.rpc.Call(ctx, "create_wallet", p, nil)
+}
+
+// RefreshResult is the response of `refresh`.
+type RefreshResult struct {
+	BlocksFetched uint64 `json:"blocks_fetched"`
+	ReceivedMoney bool   `json:"received_money"`
+}
+
+// Refresh calls `refresh` to resync the wallet against the daemon.
+func (w *Client) Refresh(ctx context.Context, startHeight uint64) (*RefreshResult, error) {
+	var result RefreshResult
+	params := map[string]interface{}{"start_height": startHeight}
+	if err := w.rpc.Call(ctx, "refresh", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
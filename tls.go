@@ -0,0 +1,151 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+// tlsServerConfig is built from TLS_* environment variables and describes
+// how to serve the Gin frontend over HTTPS.
+type tlsServerConfig struct {
+	CertFile     string
+	KeyFile      string
+	DataDir      string
+	ClientCAFile string
+	RequireMTLS  bool
+}
+
+func tlsServerConfigFromEnv() tlsServerConfig {
+	dataDir := os.Getenv("TLS_DATA_DIR")
+	if dataDir == "" {
+		dataDir = "."
+	}
+
+	return tlsServerConfig{
+		CertFile:     os.Getenv("TLS_CERT_FILE"),
+		KeyFile:      os.Getenv("TLS_KEY_FILE"),
+		DataDir:      dataDir,
+		ClientCAFile: os.Getenv("TLS_CLIENT_CA_FILE"),
+		RequireMTLS:  os.Getenv("TLS_REQUIRE_CLIENT_CERT") == "true",
+	}
+}
+
+// runTLS serves router over HTTPS at addr according to cfg, generating a
+// self-signed server certificate on first run if CertFile/KeyFile are not
+// configured or don't exist yet.
+func runTLS(router *gin.Engine, addr string, cfg tlsServerConfig) error {
+	certFile, keyFile := cfg.CertFile, cfg.KeyFile
+	if certFile == "" {
+		certFile = filepath.Join(cfg.DataDir, "server.crt")
+	}
+	if keyFile == "" {
+		keyFile = filepath.Join(cfg.DataDir, "server.key")
+	}
+
+	if _, err := os.Stat(certFile); os.IsNotExist(err) {
+		log.Println("No TLS certificate found, generating a self-signed one at", certFile)
+		if err := generateSelfSignedCert(certFile, keyFile); err != nil {
+			return fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if cfg.ClientCAFile != "" {
+		caCert, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return fmt.Errorf("failed to parse client CA bundle at %s", cfg.ClientCAFile)
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		log.Println("Mutual TLS enabled, client certificates will be verified against", cfg.ClientCAFile)
+	} else if cfg.RequireMTLS {
+		return fmt.Errorf("TLS_REQUIRE_CLIENT_CERT is set but TLS_CLIENT_CA_FILE was not provided")
+	}
+
+	server := &http.Server{
+		Addr:      addr,
+		Handler:   router,
+		TLSConfig: tlsConfig,
+	}
+
+	return server.ListenAndServeTLS(certFile, keyFile)
+}
+
+// generateSelfSignedCert writes a self-signed ECDSA cert/key pair valid for
+// one year to certFile/keyFile, creating their parent directory if needed.
+func generateSelfSignedCert(certFile, keyFile string) error {
+	if err := os.MkdirAll(filepath.Dir(certFile), 0o700); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0o700); err != nil {
+		return err
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "xmr-be self-signed"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+		DNSNames:     []string{"localhost"},
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return err
+	}
+
+	certOut, err := os.OpenFile(certFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer certOut.Close()
+	if err := pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
+		return err
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return err
+	}
+
+	keyOut, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer keyOut.Close()
+	return pem.Encode(keyOut, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+}